@@ -0,0 +1,209 @@
+package actions
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateServiceAccount mints a new service-account user together with its
+// first API key (role_id + secret_id), modeled after AppRole-style auth.
+type CreateServiceAccount struct {
+	Name       string   `json:"name"`
+	RoleID     int      `json:"roleID"`
+	TTLSeconds int      `json:"ttlSeconds"`
+	BoundCIDRs []string `json:"boundCIDRs"`
+	MaxUses    int      `json:"maxUses"`
+}
+
+// IsAuthorized returns true if user is allowed to mint service accounts.
+// Administrators always may; so may anyone whose assigned CustomRoles carry
+// PermissionAPIKeyManage (see authz.PermissionsMiddleware).
+func (a *CreateServiceAccount) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionAPIKeyManage))
+}
+
+// Validate checks the service account name, TTL, CIDR bounds and that the
+// assigned role exists in the current tenant.
+func (a *CreateServiceAccount) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(a.Name) > 100 {
+		result.AddFieldFailure("name", "Name must be 100 characters or less.")
+	}
+
+	if a.TTLSeconds < 0 {
+		result.AddFieldFailure("ttlSeconds", "TTL cannot be negative.")
+	}
+
+	if a.MaxUses < 0 {
+		result.AddFieldFailure("maxUses", "Max uses cannot be negative.")
+	}
+
+	for _, cidr := range a.BoundCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			result.AddFieldFailure("boundCIDRs", "Invalid CIDR: "+cidr)
+			break
+		}
+	}
+
+	if a.RoleID != 0 {
+		getRole := &query.GetRoleByID{RoleID: a.RoleID}
+		if err := bus.Dispatch(ctx, getRole); err != nil || getRole.Result.TenantID != user.Tenant.ID {
+			result.AddFieldFailure("roleID", "Role not found.")
+		}
+	}
+
+	return result
+}
+
+// Execute mints the service account user, assigns it RoleID when set, and
+// creates its first API key, returning the plaintext secret (it is never
+// stored or retrievable again) via the dispatched command's Result.
+func (a *CreateServiceAccount) Execute(ctx context.Context, user *entity.User) (*cmd.CreateAPIKey, error) {
+	createUser := &cmd.CreateUser{
+		TenantID: user.Tenant.ID,
+		Name:     a.Name,
+		Kind:     enum.UserKindServiceAccount,
+	}
+	if err := bus.Dispatch(ctx, createUser); err != nil {
+		return nil, err
+	}
+
+	if a.RoleID != 0 {
+		assign := &cmd.AssignUserRoles{TenantID: user.Tenant.ID, UserID: createUser.Result.ID, RoleIDs: []int{a.RoleID}}
+		if err := bus.Dispatch(ctx, assign); err != nil {
+			_ = recordAudit(ctx, user, "apikey.create_failed", createUser.Result.ID, nil, a.Name)
+			return nil, err
+		}
+	}
+
+	create := &cmd.CreateAPIKey{
+		TenantID:   user.Tenant.ID,
+		UserID:     createUser.Result.ID,
+		Name:       a.Name,
+		BoundCIDRs: a.BoundCIDRs,
+	}
+	if a.MaxUses > 0 {
+		create.UsesRemaining = &a.MaxUses
+	}
+	if a.TTLSeconds > 0 {
+		expiresAt := time.Now().Add(time.Duration(a.TTLSeconds) * time.Second)
+		create.ExpiresAt = &expiresAt
+	}
+	if err := bus.Dispatch(ctx, create); err != nil {
+		_ = recordAudit(ctx, user, "apikey.create_failed", createUser.Result.ID, nil, a.Name)
+		return nil, err
+	}
+	if err := recordAudit(ctx, user, "apikey.create", create.Result.APIKeyID, nil, a.Name); err != nil {
+		return nil, err
+	}
+	return create, nil
+}
+
+// apiKeyAction is embedded by the actions below; they all share the same
+// tenant-scoped lookup and authorization rules.
+type apiKeyAction struct {
+	APIKeyID int `json:"apiKeyID"`
+}
+
+func (a *apiKeyAction) isAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionAPIKeyManage))
+}
+
+func (a *apiKeyAction) validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getKey := &query.GetAPIKeyByID{APIKeyID: a.APIKeyID}
+	if err := bus.Dispatch(ctx, getKey); err != nil {
+		result.AddFieldFailure("apiKeyID", "API key not found.")
+		return result
+	}
+
+	if getKey.Result.TenantID != user.Tenant.ID {
+		result.AddFieldFailure("apiKeyID", "API key not found.")
+	}
+
+	return result
+}
+
+// RotateAPIKey invalidates an existing API key's secret and generates a new one.
+type RotateAPIKey struct {
+	apiKeyAction
+}
+
+func (a *RotateAPIKey) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return a.isAuthorized(ctx, user)
+}
+
+func (a *RotateAPIKey) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return a.validate(ctx, user)
+}
+
+// Execute generates and persists a new secret and returns it in plaintext.
+func (a *RotateAPIKey) Execute(ctx context.Context, user *entity.User) (string, error) {
+	rotate := &cmd.RotateAPIKeySecret{TenantID: user.Tenant.ID, APIKeyID: a.APIKeyID}
+	if err := bus.Dispatch(ctx, rotate); err != nil {
+		return "", err
+	}
+	if err := recordAudit(ctx, user, "apikey.rotate", a.APIKeyID, nil, nil); err != nil {
+		return "", err
+	}
+	return rotate.Result, nil
+}
+
+// RevokeAPIKey permanently deletes an API key.
+type RevokeAPIKey struct {
+	apiKeyAction
+}
+
+func (a *RevokeAPIKey) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return a.isAuthorized(ctx, user)
+}
+
+func (a *RevokeAPIKey) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return a.validate(ctx, user)
+}
+
+// Execute revokes the API key and records an audit log entry.
+func (a *RevokeAPIKey) Execute(ctx context.Context, user *entity.User) error {
+	if err := bus.Dispatch(ctx, &cmd.RevokeAPIKey{TenantID: user.Tenant.ID, APIKeyID: a.APIKeyID}); err != nil {
+		return err
+	}
+	return recordAudit(ctx, user, "apikey.revoke", a.APIKeyID, nil, nil)
+}
+
+// ListAPIKeys lists every API key belonging to the current tenant.
+type ListAPIKeys struct {
+	Result []*entity.APIKey
+}
+
+// IsAuthorized returns true if user is allowed to list API keys.
+func (a *ListAPIKeys) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionAPIKeyManage))
+}
+
+// Validate has nothing to check; ListAPIKeys takes no input.
+func (a *ListAPIKeys) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	return validate.Success()
+}
+
+// Execute fetches every API key belonging to the current tenant.
+func (a *ListAPIKeys) Execute(ctx context.Context, user *entity.User) error {
+	list := &query.ListAPIKeysByTenant{TenantID: user.Tenant.ID}
+	if err := bus.Dispatch(ctx, list); err != nil {
+		return err
+	}
+	a.Result = list.Result
+	return nil
+}
@@ -0,0 +1,331 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestCreateServiceAccount_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.CreateServiceAccount{Name: "CI Bot"}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionAPIKeyManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestCreateServiceAccount_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.CreateServiceAccount{Name: "CI Bot"}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestCreateServiceAccount_CollaboratorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.CreateServiceAccount{Name: "CI Bot"}
+	Expect(action.IsAuthorized(context.Background(), collaborator)).IsFalse()
+}
+
+func TestCreateServiceAccount_AdminAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.CreateServiceAccount{Name: "CI Bot"}
+	Expect(action.IsAuthorized(context.Background(), admin)).IsTrue()
+}
+
+func TestCreateServiceAccount_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	testCases := []struct {
+		expected []string
+		action   *actions.CreateServiceAccount
+	}{
+		{
+			expected: []string{"name"},
+			action:   &actions.CreateServiceAccount{},
+		},
+		{
+			expected: []string{"ttlSeconds"},
+			action:   &actions.CreateServiceAccount{Name: "CI Bot", TTLSeconds: -1},
+		},
+		{
+			expected: []string{"maxUses"},
+			action:   &actions.CreateServiceAccount{Name: "CI Bot", MaxUses: -1},
+		},
+		{
+			expected: []string{"boundCIDRs"},
+			action:   &actions.CreateServiceAccount{Name: "CI Bot", BoundCIDRs: []string{"not-a-cidr"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), currentUser)
+		ExpectFailed(result, testCase.expected...)
+	}
+}
+
+func TestCreateServiceAccount_UnknownRole(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateServiceAccount{Name: "CI Bot", RoleID: 999}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "roleID")
+}
+
+func TestCreateServiceAccount_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	role := &entity.CustomRole{ID: 1, TenantID: 1}
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == role.ID {
+			q.Result = role
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateServiceAccount{
+		Name:       "CI Bot",
+		RoleID:     role.ID,
+		TTLSeconds: 3600,
+		BoundCIDRs: []string{"10.0.0.0/8"},
+		MaxUses:    100,
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+}
+
+func TestCreateServiceAccount_Execute(t *testing.T) {
+	RegisterT(t)
+
+	role := &entity.CustomRole{ID: 1, TenantID: 1}
+	var createdUser *cmd.CreateUser
+	var assigned *cmd.AssignUserRoles
+	var createdKey *cmd.CreateAPIKey
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUser) error {
+		c.Result = &entity.User{ID: 9, Name: c.Name, Kind: c.Kind}
+		createdUser = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignUserRoles) error {
+		assigned = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateAPIKey) error {
+		c.Result.APIKeyID = 5
+		c.Result.RoleID = "role-id"
+		c.Result.Secret = "plaintext-secret"
+		createdKey = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateServiceAccount{Name: "CI Bot", RoleID: role.ID}
+	result, err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(createdUser.Kind).Equals(enum.UserKindServiceAccount)
+	Expect(assigned.UserID).Equals(9)
+	Expect(assigned.RoleIDs).Equals([]int{role.ID})
+	Expect(createdKey.UserID).Equals(9)
+	Expect(result.Result.Secret).Equals("plaintext-secret")
+}
+
+func TestRevokeAPIKey_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.RevokeAPIKey{}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestRevokeAPIKey_CrossTenantRejected(t *testing.T) {
+	RegisterT(t)
+
+	key := &entity.APIKey{ID: 1, TenantID: 1}
+	bus.AddHandler(func(ctx context.Context, q *query.GetAPIKeyByID) error {
+		if q.APIKeyID == key.ID {
+			q.Result = key
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 2}, Role: enum.RoleAdministrator}
+	action := &actions.RevokeAPIKey{}
+	action.APIKeyID = key.ID
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "apiKeyID")
+}
+
+func TestRevokeAPIKey_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	key := &entity.APIKey{ID: 1, TenantID: 1}
+	bus.AddHandler(func(ctx context.Context, q *query.GetAPIKeyByID) error {
+		if q.APIKeyID == key.ID {
+			q.Result = key
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.RevokeAPIKey{}
+	action.APIKeyID = key.ID
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+}
+
+func TestRevokeAPIKey_Execute(t *testing.T) {
+	RegisterT(t)
+
+	var revoked *cmd.RevokeAPIKey
+	var audited *cmd.RecordAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.RevokeAPIKey) error {
+		revoked = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		audited = c
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.RevokeAPIKey{}
+	action.APIKeyID = 7
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(revoked.APIKeyID).Equals(7)
+	Expect(audited.Action).Equals("apikey.revoke")
+}
+
+func TestRotateAPIKey_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.RotateAPIKey{}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestRotateAPIKey_AdminAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.RotateAPIKey{}
+	Expect(action.IsAuthorized(context.Background(), admin)).IsTrue()
+}
+
+func TestRotateAPIKey_CrossTenantRejected(t *testing.T) {
+	RegisterT(t)
+
+	key := &entity.APIKey{ID: 1, TenantID: 1}
+	bus.AddHandler(func(ctx context.Context, q *query.GetAPIKeyByID) error {
+		if q.APIKeyID == key.ID {
+			q.Result = key
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 2}, Role: enum.RoleAdministrator}
+	action := &actions.RotateAPIKey{}
+	action.APIKeyID = key.ID
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "apiKeyID")
+}
+
+func TestRotateAPIKey_Execute(t *testing.T) {
+	RegisterT(t)
+
+	var audited *cmd.RecordAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.RotateAPIKeySecret) error {
+		c.Result = "new-secret"
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		audited = c
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.RotateAPIKey{}
+	action.APIKeyID = 7
+	secret, err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(secret).Equals("new-secret")
+	Expect(audited.Action).Equals("apikey.rotate")
+}
+
+func TestListAPIKeys_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.ListAPIKeys{}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestListAPIKeys_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.ListAPIKeys{}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionAPIKeyManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestListAPIKeys_AdminAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	admin := &entity.User{ID: 1, Role: enum.RoleAdministrator}
+	action := &actions.ListAPIKeys{}
+	Expect(action.IsAuthorized(context.Background(), admin)).IsTrue()
+}
+
+func TestListAPIKeys_Execute(t *testing.T) {
+	RegisterT(t)
+
+	keys := []*entity.APIKey{{ID: 1, TenantID: 1}, {ID: 2, TenantID: 1}}
+	bus.AddHandler(func(ctx context.Context, q *query.ListAPIKeysByTenant) error {
+		q.Result = keys
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ListAPIKeys{}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(action.Result).Equals(keys)
+}
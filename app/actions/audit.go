@@ -0,0 +1,27 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/web"
+)
+
+// recordAudit dispatches a cmd.RecordAuditLog entry for a sensitive action
+// taken by actor against targetID. It's called from Execute, after the
+// underlying change has been dispatched, by every action the audit trail
+// is required to cover (ChangeUserRole, SetUserCustomFields, CreateUser,
+// DeleteUser, and API key mint/revoke).
+func recordAudit(ctx context.Context, actor *entity.User, action string, targetID int, before, after interface{}) error {
+	return bus.Dispatch(ctx, &cmd.RecordAuditLog{
+		TenantID: actor.Tenant.ID,
+		ActorID:  actor.ID,
+		TargetID: targetID,
+		Action:   action,
+		Before:   before,
+		After:    after,
+		IP:       web.RemoteIPFromContext(ctx),
+	})
+}
@@ -0,0 +1,205 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// maxBulkRows bounds how many rows a single bulk action accepts, to keep a
+// batch reasonably sized.
+const maxBulkRows = 1000
+
+// BulkRowResult reports the outcome of validating a single row of a bulk
+// action. Validate sets one for every row, even on a non-DryRun call that
+// ends up aborting the whole batch, so callers can see exactly which row
+// failed; a batch over maxBulkRows is rejected before any row is checked.
+type BulkRowResult struct {
+	Index int    `json:"index"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func firstFailureMessage(result *validate.Result) string {
+	if result.Err != nil {
+		return result.Err.Error()
+	}
+	for field, messages := range result.Failures {
+		if len(messages) > 0 {
+			return field + ": " + messages[0]
+		}
+	}
+	return "validation failed"
+}
+
+// BulkUserRoleItem is a single row of a BulkChangeUserRole request.
+type BulkUserRoleItem struct {
+	UserID int       `json:"userID"`
+	Role   enum.Role `json:"role"`
+}
+
+// BulkChangeUserRole applies actions.ChangeUserRole to many users in one
+// batch. When DryRun is true, Validate runs every row's checks and
+// populates Result without mutating anything. When DryRun is false, a
+// single invalid row aborts the entire batch. Execute applies the rows one
+// at a time and not inside a transaction: when a row fails there, the rows
+// before it stay applied.
+type BulkChangeUserRole struct {
+	Items  []BulkUserRoleItem `json:"items"`
+	DryRun bool               `json:"dryRun"`
+
+	Result []BulkRowResult `json:"result,omitempty"`
+}
+
+// IsAuthorized returns true if user is allowed to bulk change roles.
+func (a *BulkChangeUserRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionUserManage))
+}
+
+// Validate runs actions.ChangeUserRole's validation against every row.
+func (a *BulkChangeUserRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(a.Items) > maxBulkRows {
+		result.AddFieldFailure("items", fmt.Sprintf("A maximum of %d rows is allowed per batch.", maxBulkRows))
+		return result
+	}
+
+	rows := make([]BulkRowResult, len(a.Items))
+	anyFailed := false
+
+	for i, item := range a.Items {
+		row := &ChangeUserRole{UserID: item.UserID, Role: item.Role}
+		if !row.IsAuthorized(ctx, user) {
+			rows[i] = BulkRowResult{Index: i, Error: "not authorized to change this user's role"}
+			anyFailed = true
+			continue
+		}
+
+		rowResult := row.Validate(ctx, user)
+		if !rowResult.Ok() {
+			rows[i] = BulkRowResult{Index: i, Error: firstFailureMessage(rowResult)}
+			anyFailed = true
+			continue
+		}
+
+		rows[i] = BulkRowResult{Index: i, OK: true}
+	}
+
+	a.Result = rows
+
+	if anyFailed && !a.DryRun {
+		result.AddFieldFailure("items", "One or more rows failed validation; the batch was not applied.")
+	}
+
+	return result
+}
+
+// Execute applies every row's role change in turn, aborting on the first
+// failure (Validate guarantees every row already passed when DryRun is
+// false). A DryRun request never reaches here with mutations: Execute
+// no-ops so a dry run stays side-effect free even if the caller invokes
+// it directly.
+func (a *BulkChangeUserRole) Execute(ctx context.Context, user *entity.User) error {
+	if a.DryRun {
+		return nil
+	}
+
+	for i, item := range a.Items {
+		row := &ChangeUserRole{UserID: item.UserID, Role: item.Role}
+		if err := row.Execute(ctx, user); err != nil {
+			if i < len(a.Result) {
+				a.Result[i] = BulkRowResult{Index: i, Error: err.Error()}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// BulkCustomFieldsItem is a single row of a BulkSetUserCustomFields request.
+type BulkCustomFieldsItem struct {
+	UserID       int                    `json:"userID"`
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+// BulkSetUserCustomFields applies actions.SetUserCustomFields to many users
+// in one batch, with the same DryRun/abort-on-first-failure semantics (and
+// the same one-row-at-a-time, non-transactional Execute) as
+// BulkChangeUserRole.
+type BulkSetUserCustomFields struct {
+	Items  []BulkCustomFieldsItem `json:"items"`
+	DryRun bool                   `json:"dryRun"`
+
+	Result []BulkRowResult `json:"result,omitempty"`
+}
+
+// IsAuthorized returns true if user is allowed to bulk edit custom fields.
+func (a *BulkSetUserCustomFields) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleCollaborator || authz.HasPermission(ctx, enum.PermissionUserInvite))
+}
+
+// Validate runs actions.SetUserCustomFields's validation against every row.
+func (a *BulkSetUserCustomFields) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(a.Items) > maxBulkRows {
+		result.AddFieldFailure("items", fmt.Sprintf("A maximum of %d rows is allowed per batch.", maxBulkRows))
+		return result
+	}
+
+	rows := make([]BulkRowResult, len(a.Items))
+	anyFailed := false
+
+	for i, item := range a.Items {
+		row := &SetUserCustomFields{UserID: item.UserID, CustomFields: item.CustomFields}
+		if !row.IsAuthorized(ctx, user) {
+			rows[i] = BulkRowResult{Index: i, Error: "not authorized to edit this user's custom fields"}
+			anyFailed = true
+			continue
+		}
+
+		rowResult := row.Validate(ctx, user)
+		if !rowResult.Ok() {
+			rows[i] = BulkRowResult{Index: i, Error: firstFailureMessage(rowResult)}
+			anyFailed = true
+			continue
+		}
+
+		rows[i] = BulkRowResult{Index: i, OK: true}
+	}
+
+	a.Result = rows
+
+	if anyFailed && !a.DryRun {
+		result.AddFieldFailure("items", "One or more rows failed validation; the batch was not applied.")
+	}
+
+	return result
+}
+
+// Execute applies every row's custom fields in turn, aborting on the
+// first failure (Validate guarantees every row already passed when DryRun
+// is false). A DryRun request never reaches here with mutations: Execute
+// no-ops so a dry run stays side-effect free even if the caller invokes
+// it directly.
+func (a *BulkSetUserCustomFields) Execute(ctx context.Context, user *entity.User) error {
+	if a.DryRun {
+		return nil
+	}
+
+	for i, item := range a.Items {
+		row := &SetUserCustomFields{UserID: item.UserID, CustomFields: item.CustomFields}
+		if err := row.Execute(ctx, user); err != nil {
+			if i < len(a.Result) {
+				a.Result[i] = BulkRowResult{Index: i, Error: err.Error()}
+			}
+			return err
+		}
+	}
+	return nil
+}
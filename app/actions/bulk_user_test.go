@@ -0,0 +1,225 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestBulkChangeUserRole_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.BulkChangeUserRole{}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestBulkSetUserCustomFields_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.BulkSetUserCustomFields{}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserInvite))
+	Expect(action.IsAuthorized(ctx, visitor)).IsTrue()
+}
+
+func TestBulkChangeUserRole_TooManyRows(t *testing.T) {
+	RegisterT(t)
+
+	items := make([]actions.BulkUserRoleItem, 1001)
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.BulkChangeUserRole{Items: items}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "items")
+}
+
+func TestBulkChangeUserRole_DryRunDoesNotAbort(t *testing.T) {
+	RegisterT(t)
+
+	validUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == validUser.ID {
+			q.Result = validUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.BulkChangeUserRole{
+		DryRun: true,
+		Items: []actions.BulkUserRoleItem{
+			{UserID: validUser.ID, Role: enum.RoleCollaborator},
+			{UserID: 999, Role: enum.RoleCollaborator},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+	Expect(action.Result[0].OK).IsTrue()
+	Expect(action.Result[1].OK).IsFalse()
+}
+
+func TestBulkChangeUserRole_InvalidRowAbortsBatch(t *testing.T) {
+	RegisterT(t)
+
+	validUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == validUser.ID {
+			q.Result = validUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.BulkChangeUserRole{
+		Items: []actions.BulkUserRoleItem{
+			{UserID: validUser.ID, Role: enum.RoleCollaborator},
+			{UserID: 999, Role: enum.RoleCollaborator},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "items")
+}
+
+func TestBulkSetUserCustomFields_CrossTenantRowFails(t *testing.T) {
+	RegisterT(t)
+
+	otherTenantUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 9}}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == otherTenantUser.ID {
+			q.Result = otherTenantUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.BulkSetUserCustomFields{
+		DryRun: true,
+		Items: []actions.BulkCustomFieldsItem{
+			{UserID: otherTenantUser.ID, CustomFields: map[string]interface{}{"mrr": float64(10)}},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+	Expect(action.Result[0].OK).IsFalse()
+}
+
+func TestBulkChangeUserRole_Execute_DryRunDoesNotMutate(t *testing.T) {
+	RegisterT(t)
+
+	called := false
+	bus.AddHandler(func(ctx context.Context, q *query.GetRolesByTenant) error {
+		called = true
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.BulkChangeUserRole{
+		DryRun: true,
+		Items:  []actions.BulkUserRoleItem{{UserID: 2, Role: enum.RoleCollaborator}},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(called).IsFalse()
+}
+
+func TestBulkChangeUserRole_Execute_AppliesEveryRow(t *testing.T) {
+	RegisterT(t)
+
+	systemRole := &entity.CustomRole{ID: 5, TenantID: 1, IsSystem: true, BuiltIn: enum.RoleCollaborator}
+	assignCount := 0
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRolesByTenant) error {
+		q.Result = []*entity.CustomRole{systemRole}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == systemRole.ID {
+			q.Result = systemRole
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignUserRoles) error {
+		assignCount++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.BulkChangeUserRole{
+		Items: []actions.BulkUserRoleItem{
+			{UserID: 2, Role: enum.RoleCollaborator},
+			{UserID: 3, Role: enum.RoleCollaborator},
+		},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(assignCount).Equals(2)
+}
+
+func TestBulkSetUserCustomFields_Execute_DryRunDoesNotMutate(t *testing.T) {
+	RegisterT(t)
+
+	called := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetUserCustomFields) error {
+		called = true
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.BulkSetUserCustomFields{
+		DryRun: true,
+		Items:  []actions.BulkCustomFieldsItem{{UserID: 2, CustomFields: map[string]interface{}{"mrr": float64(10)}}},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(called).IsFalse()
+}
+
+func TestBulkSetUserCustomFields_Execute_AppliesEveryRow(t *testing.T) {
+	RegisterT(t)
+
+	setCount := 0
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetUserCustomFields) error {
+		setCount++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.BulkSetUserCustomFields{
+		Items: []actions.BulkCustomFieldsItem{
+			{UserID: 2, CustomFields: map[string]interface{}{"mrr": float64(10)}},
+			{UserID: 3, CustomFields: map[string]interface{}{"mrr": float64(20)}},
+		},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(setCount).Equals(2)
+}
@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// DefineCustomField registers a new tenant-level custom field, or replaces
+// the definition of an existing one with the same Name.
+type DefineCustomField struct {
+	Name       string               `json:"name"`
+	Type       enum.CustomFieldType `json:"type"`
+	Min        *float64             `json:"min"`
+	Max        *float64             `json:"max"`
+	Regex      string               `json:"regex"`
+	EnumValues []string             `json:"enumValues"`
+	Required   bool                 `json:"required"`
+	Indexed    bool                 `json:"indexed"`
+	PII        bool                 `json:"pii"`
+}
+
+// IsAuthorized returns true if user is allowed to manage the custom field schema.
+func (a *DefineCustomField) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionCustomFieldManage))
+}
+
+// Validate checks the field name, declared type and the constraints that
+// only make sense together with it (e.g. an enum field needs EnumValues).
+func (a *DefineCustomField) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(a.Name) > 100 {
+		result.AddFieldFailure("name", "Name must be 100 characters or less.")
+	}
+
+	if !a.Type.IsValid() {
+		result.AddFieldFailure("type", "Unknown field type.")
+		return result
+	}
+
+	if a.Type == enum.CustomFieldEnum && len(a.EnumValues) == 0 {
+		result.AddFieldFailure("enumValues", "Enum fields require at least one allowed value.")
+	}
+
+	if a.Regex != "" && a.Type != enum.CustomFieldString {
+		result.AddFieldFailure("regex", "Regex constraints only apply to string fields.")
+	}
+
+	return result
+}
+
+// Execute persists the field definition.
+func (a *DefineCustomField) Execute(ctx context.Context, user *entity.User) error {
+	return bus.Dispatch(ctx, &cmd.DefineCustomField{
+		TenantID: user.Tenant.ID,
+		Definition: entity.CustomFieldDefinition{
+			Name:       a.Name,
+			Type:       a.Type,
+			Min:        a.Min,
+			Max:        a.Max,
+			Regex:      a.Regex,
+			EnumValues: a.EnumValues,
+			Required:   a.Required,
+			Indexed:    a.Indexed,
+			PII:        a.PII,
+		},
+	})
+}
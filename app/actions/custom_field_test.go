@@ -0,0 +1,100 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestDefineCustomField_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.DefineCustomField{Name: "mrr", Type: enum.CustomFieldNumber}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionCustomFieldManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestDefineCustomField_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.DefineCustomField{Name: "mrr", Type: enum.CustomFieldNumber}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestDefineCustomField_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	testCases := []struct {
+		expected []string
+		action   *actions.DefineCustomField
+	}{
+		{
+			expected: []string{"name"},
+			action:   &actions.DefineCustomField{Type: enum.CustomFieldString},
+		},
+		{
+			expected: []string{"type"},
+			action:   &actions.DefineCustomField{Name: "mrr", Type: "not-a-type"},
+		},
+		{
+			expected: []string{"enumValues"},
+			action:   &actions.DefineCustomField{Name: "tier", Type: enum.CustomFieldEnum},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), currentUser)
+		ExpectFailed(result, testCase.expected...)
+	}
+}
+
+func TestDefineCustomField_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.DefineCustomField{
+		Name:       "tier",
+		Type:       enum.CustomFieldEnum,
+		EnumValues: []string{"free", "vip"},
+		Indexed:    true,
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+}
+
+func TestDefineCustomField_Execute(t *testing.T) {
+	RegisterT(t)
+
+	var dispatched *cmd.DefineCustomField
+	bus.AddHandler(func(ctx context.Context, c *cmd.DefineCustomField) error {
+		dispatched = c
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.DefineCustomField{
+		Name:       "tier",
+		Type:       enum.CustomFieldEnum,
+		EnumValues: []string{"free", "vip"},
+		Indexed:    true,
+		PII:        true,
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(dispatched.TenantID).Equals(1)
+	Expect(dispatched.Definition.Name).Equals("tier")
+	Expect(dispatched.Definition.PII).IsTrue()
+}
@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// DeleteUser permanently removes a user from the current tenant, cascading
+// the deletion through every subsystem that references them: role
+// assignments, API keys, custom fields, authored content, active sessions
+// and OAuth provider links.
+type DeleteUser struct {
+	UserID int `json:"userID"`
+
+	// AnonymizeMode controls what happens to content the user authored.
+	// Defaults to enum.AnonymizeModeReassign when left empty.
+	AnonymizeMode enum.AnonymizeMode `json:"anonymizeMode"`
+}
+
+// IsAuthorized returns true if user is allowed to delete another user.
+// Administrators, and anyone holding PermissionUserManage (see
+// authz.PermissionsMiddleware), may delete anyone except themselves.
+func (a *DeleteUser) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.ID != a.UserID && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionUserManage))
+}
+
+// Validate checks that the target user exists, belongs to the current
+// tenant, and isn't the caller themselves.
+func (a *DeleteUser) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.Tenant.ID != user.Tenant.ID {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.ID == user.ID {
+		result.AddFieldFailure("userID", "You cannot delete your own account.")
+		return result
+	}
+
+	if a.AnonymizeMode != "" && !a.AnonymizeMode.IsValid() {
+		result.AddFieldFailure("anonymizeMode", "Unknown anonymize mode.")
+	}
+
+	return result
+}
+
+// Execute runs the full cascade as a single unit of work, then records an
+// audit log entry. Every cascade step is dispatched exactly once. The
+// backing store is expected to run these steps inside a single database
+// transaction; Execute itself aborts on the first failing step but cannot
+// roll back steps a prior dispatch has already committed.
+func (a *DeleteUser) Execute(ctx context.Context, user *entity.User) error {
+	mode := a.AnonymizeMode
+	if mode == "" {
+		mode = enum.AnonymizeModeReassign
+	}
+
+	tenantID := user.Tenant.ID
+
+	var before *entity.User
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err == nil {
+		before = getUser.Result
+	}
+
+	steps := []interface{}{
+		&cmd.RemoveUserRoleAssignments{TenantID: tenantID, UserID: a.UserID},
+		&cmd.RevokeAllAPIKeysForUser{TenantID: tenantID, UserID: a.UserID},
+		&cmd.WipeUserCustomFields{TenantID: tenantID, UserID: a.UserID},
+		&cmd.AnonymizeUserContent{TenantID: tenantID, UserID: a.UserID, Mode: mode},
+		&cmd.InvalidateUserSessions{TenantID: tenantID, UserID: a.UserID},
+		&cmd.UnlinkOAuthProviders{TenantID: tenantID, UserID: a.UserID},
+		&cmd.DeleteUserRecord{TenantID: tenantID, UserID: a.UserID},
+	}
+
+	for _, step := range steps {
+		if err := bus.Dispatch(ctx, step); err != nil {
+			_ = recordAudit(ctx, user, "user.delete_failed", a.UserID, before, nil)
+			return err
+		}
+	}
+
+	return recordAudit(ctx, user, "user.delete", a.UserID, before, nil)
+}
@@ -0,0 +1,106 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestDeleteUser_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+		{ID: 2, Role: enum.RoleAdministrator},
+	} {
+		action := &actions.DeleteUser{UserID: 2}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+}
+
+func TestDeleteUser_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.DeleteUser{UserID: 2}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestDeleteUser_CrossTenantRejected(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 2}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.DeleteUser{UserID: targetUser.ID}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+}
+
+func TestDeleteUser_CascadeStepsDispatchedExactlyOnce(t *testing.T) {
+	RegisterT(t)
+
+	counts := map[string]int{}
+
+	bus.AddHandler(func(ctx context.Context, c *cmd.RemoveUserRoleAssignments) error {
+		counts["roles"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RevokeAllAPIKeysForUser) error {
+		counts["apikeys"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.WipeUserCustomFields) error {
+		counts["customfields"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AnonymizeUserContent) error {
+		counts["content"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.InvalidateUserSessions) error {
+		counts["sessions"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.UnlinkOAuthProviders) error {
+		counts["oauth"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.DeleteUserRecord) error {
+		counts["record"]++
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		counts["audit"]++
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.DeleteUser{UserID: 2}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	for _, step := range []string{"roles", "apikeys", "customfields", "content", "sessions", "oauth", "record", "audit"} {
+		Expect(counts[step]).Equals(1)
+	}
+}
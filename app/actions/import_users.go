@@ -0,0 +1,155 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// ImportUserRow is a single row of an ImportUsers request, typically parsed
+// from a CSV or JSON upload.
+type ImportUserRow struct {
+	Name         string                 `json:"name"`
+	Email        string                 `json:"email"`
+	Reference    string                 `json:"reference"`
+	Role         enum.Role              `json:"role"`
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+// ImportUsers bulk-creates users from a CSV/JSON payload, delegating each
+// row's field validation to actions.CreateUser. Like the other bulk
+// actions, DryRun runs validation and populates Result without creating
+// anything, while a non-DryRun call aborts entirely on the first bad row.
+type ImportUsers struct {
+	Rows   []ImportUserRow `json:"rows"`
+	DryRun bool            `json:"dryRun"`
+
+	Result []BulkRowResult `json:"result,omitempty"`
+}
+
+// IsAuthorized returns true if user is allowed to import users, mirroring
+// actions.CreateUser's own requirement.
+func (a *ImportUsers) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleCollaborator || authz.HasPermission(ctx, enum.PermissionUserInvite))
+}
+
+// Validate checks every row's name/email/reference (via actions.CreateUser),
+// role and custom fields.
+func (a *ImportUsers) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if len(a.Rows) > maxBulkRows {
+		result.AddFieldFailure("rows", "A maximum of 1000 rows is allowed per import.")
+		return result
+	}
+
+	var schema *entity.CustomFieldSchema
+	getSchema := &query.GetCustomFieldSchema{TenantID: user.Tenant.ID}
+	if err := bus.Dispatch(ctx, getSchema); err == nil {
+		schema = getSchema.Result
+	}
+
+	rows := make([]BulkRowResult, len(a.Rows))
+	anyFailed := false
+
+	for i, row := range a.Rows {
+		rowResult := (&CreateUser{Name: row.Name, Email: row.Email, Reference: row.Reference}).Validate(ctx, user)
+
+		if rowResult.Ok() && row.Role != 0 && !row.Role.IsValid() {
+			rowResult.AddFieldFailure("role", "Unknown role.")
+		}
+
+		if rowResult.Ok() {
+			validateImportCustomFields(rowResult, schema, row.CustomFields)
+		}
+
+		if !rowResult.Ok() {
+			rows[i] = BulkRowResult{Index: i, Error: firstFailureMessage(rowResult)}
+			anyFailed = true
+			continue
+		}
+
+		rows[i] = BulkRowResult{Index: i, OK: true}
+	}
+
+	a.Result = rows
+
+	if anyFailed && !a.DryRun {
+		result.AddFieldFailure("rows", "One or more rows failed validation; the import was not applied.")
+	}
+
+	return result
+}
+
+// validateImportCustomFields delegates to the same schema-aware check
+// SetUserCustomFields.Validate uses, so a row's custom fields are held to
+// exactly the same rules as a single-user update.
+func validateImportCustomFields(result *validate.Result, schema *entity.CustomFieldSchema, fields map[string]interface{}) {
+	if !validateCustomFieldsAgainstSchema(result, schema, fields) || schema == nil || fields == nil {
+		return
+	}
+	for _, def := range schema.Fields {
+		if !def.Required {
+			continue
+		}
+		if _, ok := fields[def.Name]; !ok {
+			result.AddFieldFailure("customFields."+def.Name, "This field is required.")
+		}
+	}
+}
+
+// Execute creates every row's user in turn, aborting on the first failure
+// (Validate guarantees every row already passed when DryRun is false). A
+// DryRun request never reaches here with mutations: Execute no-ops so a
+// dry run stays side-effect free even if the caller invokes it directly.
+func (a *ImportUsers) Execute(ctx context.Context, user *entity.User) error {
+	if a.DryRun {
+		return nil
+	}
+
+	for i, row := range a.Rows {
+		if err := a.importRow(ctx, user, row); err != nil {
+			if i < len(a.Result) {
+				a.Result[i] = BulkRowResult{Index: i, Error: err.Error()}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// importRow creates a single row's user and, when set, applies its role
+// and custom fields -- the same sequence of steps as chaining
+// actions.CreateUser, actions.ChangeUserRole and
+// actions.SetUserCustomFields by hand.
+func (a *ImportUsers) importRow(ctx context.Context, user *entity.User, row ImportUserRow) error {
+	create := &cmd.CreateUser{TenantID: user.Tenant.ID, Name: row.Name, Email: row.Email, Reference: row.Reference}
+	if err := bus.Dispatch(ctx, create); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, user, "user.create", create.Result.ID, nil, create.Result); err != nil {
+		return err
+	}
+
+	if row.Role != 0 {
+		changeRole := &ChangeUserRole{UserID: create.Result.ID, Role: row.Role}
+		if err := changeRole.Execute(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	if row.CustomFields != nil {
+		setFields := &SetUserCustomFields{UserID: create.Result.ID, CustomFields: row.CustomFields}
+		if err := setFields.Execute(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestImportUsers_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.ImportUsers{}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserInvite))
+	Expect(action.IsAuthorized(ctx, visitor)).IsTrue()
+}
+
+func TestImportUsers_VisitorNotAuthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.ImportUsers{}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestImportUsers_DryRunReportsPerRow(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ImportUsers{
+		DryRun: true,
+		Rows: []actions.ImportUserRow{
+			{Name: "Jon Snow", Email: "jon@got.com"},
+			{Name: "", Email: "invalid"},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+	Expect(action.Result[0].OK).IsTrue()
+	Expect(action.Result[1].OK).IsFalse()
+}
+
+func TestImportUsers_InvalidRowAbortsImport(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ImportUsers{
+		Rows: []actions.ImportUserRow{
+			{Name: "Jon Snow", Email: "jon@got.com"},
+			{Name: "", Email: "invalid"},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "rows")
+}
+
+func TestImportUsers_RejectsNonPrimitiveAdditionalField(t *testing.T) {
+	RegisterT(t)
+
+	schema := &entity.CustomFieldSchema{TenantID: 1, AdditionalFields: true}
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldSchema) error {
+		q.Result = schema
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ImportUsers{
+		Rows: []actions.ImportUserRow{
+			{Name: "Jon Snow", Email: "jon@got.com", CustomFields: map[string]interface{}{"extra": []string{"a", "b"}}},
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "rows")
+	Expect(action.Result[0].OK).IsFalse()
+}
+
+func TestImportUsers_Execute_DryRunDoesNotMutate(t *testing.T) {
+	RegisterT(t)
+
+	called := false
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUser) error {
+		called = true
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ImportUsers{
+		DryRun: true,
+		Rows:   []actions.ImportUserRow{{Name: "Jon Snow", Email: "jon@got.com"}},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(called).IsFalse()
+}
+
+func TestImportUsers_Execute_CreatesEveryRow(t *testing.T) {
+	RegisterT(t)
+
+	createCount := 0
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUser) error {
+		createCount++
+		c.Result = &entity.User{ID: createCount, Tenant: &entity.Tenant{ID: c.TenantID}, Name: c.Name}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.ImportUsers{
+		Rows: []actions.ImportUserRow{
+			{Name: "Jon Snow", Email: "jon@got.com"},
+			{Name: "Arya Stark", Email: "arya@got.com"},
+		},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(createCount).Equals(2)
+}
@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+// CreateOrUpdateRole creates a new custom role, or edits an existing one
+// when RoleID is set. Seeded system roles (IsSystem) cannot be edited here.
+type CreateOrUpdateRole struct {
+	RoleID      int               `json:"roleID"`
+	Name        string            `json:"name"`
+	Permissions []enum.Permission `json:"permissions"`
+}
+
+// IsAuthorized returns true if user is allowed to manage custom roles.
+// Administrators always may; so may anyone else whose assigned CustomRoles
+// carry PermissionRoleManage (see authz.PermissionsMiddleware).
+func (a *CreateOrUpdateRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionRoleManage))
+}
+
+// Validate checks the role name, the permission list against the registered
+// catalog, and -- when editing -- that the role exists, belongs to the
+// current tenant and isn't an immutable system role.
+func (a *CreateOrUpdateRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(a.Name) > 100 {
+		result.AddFieldFailure("name", "Name must be 100 characters or less.")
+	}
+
+	for _, perm := range a.Permissions {
+		if !perm.IsValid() {
+			result.AddFieldFailure("permissions", "Unknown permission: "+string(perm))
+			break
+		}
+	}
+
+	if a.RoleID != 0 {
+		getRole := &query.GetRoleByID{RoleID: a.RoleID}
+		if err := bus.Dispatch(ctx, getRole); err != nil {
+			result.AddFieldFailure("roleID", "Role not found.")
+			return result
+		}
+
+		if getRole.Result.TenantID != user.Tenant.ID {
+			result.AddFieldFailure("roleID", "Role not found.")
+			return result
+		}
+
+		if getRole.Result.IsSystem {
+			result.AddFieldFailure("roleID", "System roles cannot be modified.")
+			return result
+		}
+	}
+
+	return result
+}
+
+// Execute persists the role definition.
+func (a *CreateOrUpdateRole) Execute(ctx context.Context, user *entity.User) error {
+	return bus.Dispatch(ctx, &cmd.CreateOrUpdateRole{
+		TenantID:    user.Tenant.ID,
+		RoleID:      a.RoleID,
+		Name:        a.Name,
+		Permissions: a.Permissions,
+	})
+}
+
+// AssignUserRoles replaces the set of custom roles assigned to a user. It
+// supersedes the single-role actions.ChangeUserRole, which remains in place
+// as a compatibility shim on top of this action for the three built-in roles.
+type AssignUserRoles struct {
+	UserID  int   `json:"userID"`
+	RoleIDs []int `json:"roleIDs"`
+}
+
+// IsAuthorized returns true if user is allowed to assign roles to another
+// user. Administrators, and anyone holding PermissionRoleManage (see
+// authz.PermissionsMiddleware), may assign roles to anyone except themselves.
+func (a *AssignUserRoles) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.ID != a.UserID && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionRoleManage))
+}
+
+// Validate checks that the target user exists in the current tenant, isn't
+// the caller themselves, and that every role ID resolves to a role in the
+// current tenant.
+func (a *AssignUserRoles) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.Tenant.ID != user.Tenant.ID {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.ID == user.ID {
+		result.AddFieldFailure("userID", "You cannot change your own roles.")
+		return result
+	}
+
+	for _, roleID := range a.RoleIDs {
+		getRole := &query.GetRoleByID{RoleID: roleID}
+		if err := bus.Dispatch(ctx, getRole); err != nil || getRole.Result.TenantID != user.Tenant.ID {
+			result.AddFieldFailure("roleIDs", "Role not found.")
+			return result
+		}
+	}
+
+	return result
+}
+
+// Execute persists the role assignment. It also resolves whichever
+// assigned role (if any) is a seeded system role and carries its
+// enum.Role forward so the handler keeps entity.User.Role in sync --
+// actions.ChangeUserRole and every other Role-gated IsAuthorized check
+// depend on that field, not on the CustomRole assignment itself.
+func (a *AssignUserRoles) Execute(ctx context.Context, user *entity.User) error {
+	var builtIn enum.Role
+	for _, roleID := range a.RoleIDs {
+		getRole := &query.GetRoleByID{RoleID: roleID}
+		if err := bus.Dispatch(ctx, getRole); err != nil {
+			return err
+		}
+		if getRole.Result.IsSystem {
+			builtIn = getRole.Result.BuiltIn
+		}
+	}
+
+	return bus.Dispatch(ctx, &cmd.AssignUserRoles{
+		TenantID:    user.Tenant.ID,
+		UserID:      a.UserID,
+		RoleIDs:     a.RoleIDs,
+		BuiltInRole: builtIn,
+	})
+}
@@ -0,0 +1,213 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func TestCreateOrUpdateRole_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+	} {
+		action := &actions.CreateOrUpdateRole{Name: "Support"}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+}
+
+func TestCreateOrUpdateRole_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.CreateOrUpdateRole{Name: "Support"}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionRoleManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestCreateOrUpdateRole_InvalidInput(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	testCases := []struct {
+		expected []string
+		action   *actions.CreateOrUpdateRole
+	}{
+		{
+			expected: []string{"name"},
+			action:   &actions.CreateOrUpdateRole{},
+		},
+		{
+			expected: []string{"permissions"},
+			action:   &actions.CreateOrUpdateRole{Name: "Support", Permissions: []enum.Permission{"not.a.real.permission"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result := testCase.action.Validate(context.Background(), currentUser)
+		ExpectFailed(result, testCase.expected...)
+	}
+}
+
+func TestCreateOrUpdateRole_SystemRoleImmutable(t *testing.T) {
+	RegisterT(t)
+
+	systemRole := &entity.CustomRole{ID: 1, TenantID: 1, IsSystem: true}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == systemRole.ID {
+			q.Result = systemRole
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateOrUpdateRole{RoleID: systemRole.ID, Name: "Renamed", Permissions: []enum.Permission{enum.PermissionPostCreate}}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "roleID")
+}
+
+func TestCreateOrUpdateRole_Execute(t *testing.T) {
+	RegisterT(t)
+
+	var dispatched *cmd.CreateOrUpdateRole
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateOrUpdateRole) error {
+		dispatched = c
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateOrUpdateRole{Name: "Support", Permissions: []enum.Permission{enum.PermissionUserInvite}}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(dispatched.Name).Equals("Support")
+	Expect(dispatched.Permissions).Equals([]enum.Permission{enum.PermissionUserInvite})
+}
+
+func TestAssignUserRoles_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	for _, user := range []*entity.User{
+		{ID: 1, Role: enum.RoleVisitor},
+		{ID: 1, Role: enum.RoleCollaborator},
+		{ID: 2, Role: enum.RoleAdministrator},
+	} {
+		action := &actions.AssignUserRoles{UserID: 2}
+		Expect(action.IsAuthorized(context.Background(), user)).IsFalse()
+	}
+}
+
+func TestAssignUserRoles_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 1, Role: enum.RoleCollaborator}
+	action := &actions.AssignUserRoles{UserID: 2}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionRoleManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
+func TestAssignUserRoles_CrossTenant(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 2}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.AssignUserRoles{UserID: targetUser.ID, RoleIDs: []int{1}}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+}
+
+func TestAssignUserRoles_SelfDemotionPrevented(t *testing.T) {
+	RegisterT(t)
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == currentUser.ID {
+			q.Result = currentUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.AssignUserRoles{UserID: currentUser.ID, RoleIDs: []int{1}}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "userID")
+}
+
+func TestAssignUserRoles_ValidInput(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	role := &entity.CustomRole{ID: 5, TenantID: 1, Name: "Support"}
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == role.ID {
+			q.Result = role
+			return nil
+		}
+		return app.ErrNotFound
+	})
+
+	action := &actions.AssignUserRoles{UserID: targetUser.ID, RoleIDs: []int{role.ID}}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+}
+
+func TestAssignUserRoles_Execute_SyncsBuiltInRole(t *testing.T) {
+	RegisterT(t)
+
+	systemRole := &entity.CustomRole{ID: 5, TenantID: 1, IsSystem: true, BuiltIn: enum.RoleAdministrator}
+	var dispatched *cmd.AssignUserRoles
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == systemRole.ID {
+			q.Result = systemRole
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignUserRoles) error {
+		dispatched = c
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.AssignUserRoles{UserID: 2, RoleIDs: []int{systemRole.ID}}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(dispatched.BuiltInRole).Equals(enum.RoleAdministrator)
+}
@@ -0,0 +1,114 @@
+package actions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+func registerCustomFieldSchema(targetUser *entity.User, schema *entity.CustomFieldSchema) {
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldSchema) error {
+		q.Result = schema
+		return nil
+	})
+}
+
+func TestSetUserCustomFields_SchemaTypeMismatch(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	schema := &entity.CustomFieldSchema{
+		Fields: []entity.CustomFieldDefinition{
+			{Name: "mrr", Type: enum.CustomFieldNumber},
+		},
+	}
+	registerCustomFieldSchema(targetUser, schema)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.SetUserCustomFields{
+		UserID:       targetUser.ID,
+		CustomFields: map[string]interface{}{"mrr": "not-a-number"},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "customFields.mrr")
+}
+
+func TestSetUserCustomFields_SchemaUnknownFieldRejected(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	schema := &entity.CustomFieldSchema{
+		Fields: []entity.CustomFieldDefinition{
+			{Name: "mrr", Type: enum.CustomFieldNumber},
+		},
+	}
+	registerCustomFieldSchema(targetUser, schema)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.SetUserCustomFields{
+		UserID:       targetUser.ID,
+		CustomFields: map[string]interface{}{"unknown": "x"},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "customFields")
+}
+
+func TestSetUserCustomFields_SchemaValidInput(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	schema := &entity.CustomFieldSchema{
+		Fields: []entity.CustomFieldDefinition{
+			{Name: "mrr", Type: enum.CustomFieldNumber},
+			{Name: "tier", Type: enum.CustomFieldEnum, EnumValues: []string{"free", "vip"}},
+			{Name: "beta", Type: enum.CustomFieldBool},
+		},
+	}
+	registerCustomFieldSchema(targetUser, schema)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.SetUserCustomFields{
+		UserID: targetUser.ID,
+		CustomFields: map[string]interface{}{
+			"mrr":  float64(100),
+			"tier": "vip",
+			"beta": true,
+		},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectSuccess(result)
+}
+
+func TestSetUserCustomFields_SchemaRequiredFieldMissing(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{ID: 2, Tenant: &entity.Tenant{ID: 1}}
+	schema := &entity.CustomFieldSchema{
+		Fields: []entity.CustomFieldDefinition{
+			{Name: "mrr", Type: enum.CustomFieldNumber, Required: true},
+		},
+	}
+	registerCustomFieldSchema(targetUser, schema)
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.SetUserCustomFields{
+		UserID:       targetUser.ID,
+		CustomFields: map[string]interface{}{},
+	}
+	result := action.Validate(context.Background(), currentUser)
+	ExpectFailed(result, "customFields.mrr")
+}
@@ -0,0 +1,377 @@
+package actions
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/getfider/fider/app"
+	"github.com/getfider/fider/app/models/cmd"
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+var emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// CreateUser creates a new user on the current tenant.
+type CreateUser struct {
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Reference string `json:"reference"`
+}
+
+// IsAuthorized returns true if user is allowed to create new users.
+func (a *CreateUser) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleCollaborator || authz.HasPermission(ctx, enum.PermissionUserInvite))
+}
+
+// Validate checks that the new user's fields are well-formed.
+func (a *CreateUser) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.Name == "" {
+		result.AddFieldFailure("name", "Name is required.")
+	} else if len(a.Name) > 100 {
+		result.AddFieldFailure("name", "Name must be 100 characters or less.")
+	}
+
+	if a.Email != "" {
+		if len(a.Email) > 200 {
+			result.AddFieldFailure("email", "Email must be 200 characters or less.")
+		} else if !emailRegex.MatchString(a.Email) {
+			result.AddFieldFailure("email", "Please enter a valid email address.")
+		}
+	}
+
+	if len(a.Reference) > 100 {
+		result.AddFieldFailure("reference", "Reference must be 100 characters or less.")
+	}
+
+	return result
+}
+
+// Execute persists the new user and records an audit log entry.
+func (a *CreateUser) Execute(ctx context.Context, user *entity.User) error {
+	create := &cmd.CreateUser{TenantID: user.Tenant.ID, Name: a.Name, Email: a.Email, Reference: a.Reference}
+	if err := bus.Dispatch(ctx, create); err != nil {
+		return err
+	}
+	return recordAudit(ctx, user, "user.create", create.Result.ID, nil, create.Result)
+}
+
+// ChangeUserRole changes the built-in role of an existing user on the current
+// tenant. It is kept as a compatibility shim over the RBAC v2 role system
+// (see actions.AssignUserRoles): Execute resolves Role to the seeded system
+// CustomRole that backs it and assigns that role instead of writing
+// entity.User.Role directly. Validation rules are unchanged so existing
+// integrations keep working exactly as before.
+type ChangeUserRole struct {
+	UserID int       `json:"userID"`
+	Role   enum.Role `json:"role"`
+}
+
+// Execute assigns the seeded system role backing a.Role to the target user.
+func (a *ChangeUserRole) Execute(ctx context.Context, user *entity.User) error {
+	getRoles := &query.GetRolesByTenant{TenantID: user.Tenant.ID}
+	if err := bus.Dispatch(ctx, getRoles); err != nil {
+		return err
+	}
+
+	for _, role := range getRoles.Result {
+		if role.IsSystem && role.BuiltIn == a.Role {
+			assign := &AssignUserRoles{UserID: a.UserID, RoleIDs: []int{role.ID}}
+			if err := assign.Execute(ctx, user); err != nil {
+				return err
+			}
+			return recordAudit(ctx, user, "user.change_role", a.UserID, nil, a.Role)
+		}
+	}
+
+	return app.ErrNotFound
+}
+
+// IsAuthorized returns true if user is allowed to change another user's role.
+// Administrators, and anyone holding PermissionUserManage (see
+// authz.PermissionsMiddleware), may change anyone's role except their own.
+func (a *ChangeUserRole) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && user.ID != a.UserID && (user.Role >= enum.RoleAdministrator || authz.HasPermission(ctx, enum.PermissionUserManage))
+}
+
+// Validate checks that the target user exists, belongs to the current tenant
+// and isn't the caller themselves.
+func (a *ChangeUserRole) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	if !a.Role.IsValid() {
+		return validate.Error(app.ErrNotFound)
+	}
+
+	result := validate.Success()
+
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.Tenant.ID != user.Tenant.ID {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.ID == user.ID {
+		result.AddFieldFailure("userID", "You cannot change your own role.")
+		return result
+	}
+
+	return result
+}
+
+// SetUserCustomFields overwrites the tenant-defined custom fields of an existing user.
+type SetUserCustomFields struct {
+	UserID       int                    `json:"userID"`
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+// IsAuthorized returns true if user is allowed to edit other users' custom fields.
+func (a *SetUserCustomFields) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleCollaborator || authz.HasPermission(ctx, enum.PermissionUserInvite))
+}
+
+// Validate checks that the target user exists and that every custom field
+// value matches the tenant's registered schema (see actions.DefineCustomField).
+// Tenants that haven't defined a schema yet fall back to the legacy rule of
+// only accepting primitive values (string, number or bool).
+func (a *SetUserCustomFields) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.UserID == 0 {
+		result.AddFieldFailure("userID", "UserID is required.")
+		return result
+	}
+
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.Tenant.ID != user.Tenant.ID {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if a.CustomFields == nil {
+		return result
+	}
+
+	var schema *entity.CustomFieldSchema
+	getSchema := &query.GetCustomFieldSchema{TenantID: user.Tenant.ID}
+	if err := bus.Dispatch(ctx, getSchema); err == nil {
+		schema = getSchema.Result
+	}
+
+	if !validateCustomFieldsAgainstSchema(result, schema, a.CustomFields) {
+		return result
+	}
+
+	if schema != nil {
+		for _, def := range schema.Fields {
+			if !def.Required {
+				continue
+			}
+			if _, ok := a.CustomFields[def.Name]; !ok {
+				result.AddFieldFailure("customFields."+def.Name, "This field is required.")
+			}
+		}
+	}
+
+	return result
+}
+
+// validateCustomFieldsAgainstSchema type-checks every value in fields
+// against schema, or -- when schema is nil, i.e. the tenant hasn't defined
+// one yet -- falls back to the legacy rule of only accepting primitive
+// values (string, number or bool). Shared by SetUserCustomFields.Validate
+// and actions.ImportUsers's per-row validation so the two can't drift out
+// of sync. Returns false if an unrecoverable failure (an unknown field, or
+// a field whose value doesn't even satisfy the primitive fallback) means
+// the caller should stop validating this batch of fields.
+func validateCustomFieldsAgainstSchema(result *validate.Result, schema *entity.CustomFieldSchema, fields map[string]interface{}) bool {
+	for name, value := range fields {
+		if schema == nil {
+			if msg := validatePrimitiveCustomFieldValue(value); msg != "" {
+				result.AddFieldFailure("customFields", msg)
+				return false
+			}
+			continue
+		}
+
+		def := schema.FieldByName(name)
+		if def == nil {
+			if !schema.AdditionalFields {
+				result.AddFieldFailure("customFields", "Unknown custom field: "+name)
+				return false
+			}
+			if msg := validatePrimitiveCustomFieldValue(value); msg != "" {
+				result.AddFieldFailure("customFields", msg)
+				return false
+			}
+			continue
+		}
+
+		if msg := validateCustomFieldValue(*def, value); msg != "" {
+			result.AddFieldFailure("customFields."+name, msg)
+		}
+	}
+	return true
+}
+
+// validatePrimitiveCustomFieldValue checks value against the legacy rule
+// used when no typed schema applies: string, number or bool only.
+func validatePrimitiveCustomFieldValue(value interface{}) string {
+	switch value.(type) {
+	case string, float64, bool:
+		return ""
+	default:
+		return "Custom field values must be a string, number or boolean."
+	}
+}
+
+// validateCustomFieldValue type-checks value against def's declared type and
+// constraints, returning a human-readable failure message, or "" if it's valid.
+func validateCustomFieldValue(def entity.CustomFieldDefinition, value interface{}) string {
+	switch def.Type {
+	case enum.CustomFieldString:
+		s, ok := value.(string)
+		if !ok {
+			return "Must be a string."
+		}
+		if def.Regex != "" {
+			if matched, err := regexp.MatchString(def.Regex, s); err != nil || !matched {
+				return "Does not match the expected format."
+			}
+		}
+	case enum.CustomFieldNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return "Must be a number."
+		}
+		if def.Min != nil && n < *def.Min {
+			return "Below the minimum allowed value."
+		}
+		if def.Max != nil && n > *def.Max {
+			return "Above the maximum allowed value."
+		}
+	case enum.CustomFieldBool:
+		if _, ok := value.(bool); !ok {
+			return "Must be a boolean."
+		}
+	case enum.CustomFieldEnum:
+		s, ok := value.(string)
+		if !ok {
+			return "Must be a string."
+		}
+		for _, allowed := range def.EnumValues {
+			if allowed == s {
+				return ""
+			}
+		}
+		return "Not one of the allowed values."
+	case enum.CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return "Must be a date string."
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "Must be an RFC3339 date."
+		}
+	case enum.CustomFieldURL:
+		s, ok := value.(string)
+		if !ok {
+			return "Must be a URL string."
+		}
+		if u, err := url.ParseRequestURI(s); err != nil || u.Scheme == "" {
+			return "Must be a valid URL."
+		}
+	}
+	return ""
+}
+
+// GetUserCustomFields reads another user's custom field values, masking any
+// field flagged PII (see entity.CustomFieldSchema.MaskPII) from callers who
+// aren't Administrators.
+type GetUserCustomFields struct {
+	UserID int `json:"userID"`
+
+	Result map[string]interface{}
+}
+
+// IsAuthorized returns true if user is allowed to read other users' custom fields.
+func (a *GetUserCustomFields) IsAuthorized(ctx context.Context, user *entity.User) bool {
+	return user != nil && (user.Role >= enum.RoleCollaborator || authz.HasPermission(ctx, enum.PermissionUserInvite))
+}
+
+// Validate checks that the target user exists and belongs to the current tenant.
+func (a *GetUserCustomFields) Validate(ctx context.Context, user *entity.User) *validate.Result {
+	result := validate.Success()
+
+	if a.UserID == 0 {
+		result.AddFieldFailure("userID", "UserID is required.")
+		return result
+	}
+
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		result.AddFieldFailure("userID", "User not found.")
+		return result
+	}
+
+	if getUser.Result.Tenant.ID != user.Tenant.ID {
+		result.AddFieldFailure("userID", "User not found.")
+	}
+
+	return result
+}
+
+// Execute fetches the target user's custom fields, masking PII-flagged
+// values from the result unless the caller is an Administrator.
+func (a *GetUserCustomFields) Execute(ctx context.Context, user *entity.User) error {
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err != nil {
+		return err
+	}
+
+	fields := getUser.Result.CustomFields
+	if user.Role < enum.RoleAdministrator {
+		getSchema := &query.GetCustomFieldSchema{TenantID: user.Tenant.ID}
+		if err := bus.Dispatch(ctx, getSchema); err != nil || getSchema.Result == nil {
+			// We can't tell which fields (if any) are PII without the
+			// schema, so withhold everything rather than risk leaking one.
+			fields = nil
+		} else {
+			fields = getSchema.Result.MaskPII(fields)
+		}
+	}
+
+	a.Result = fields
+	return nil
+}
+
+// Execute persists the custom field values and records an audit log entry,
+// capturing the values previously set so the trail shows what changed.
+func (a *SetUserCustomFields) Execute(ctx context.Context, user *entity.User) error {
+	var before map[string]interface{}
+	getUser := &query.GetUserByID{UserID: a.UserID}
+	if err := bus.Dispatch(ctx, getUser); err == nil {
+		before = getUser.Result.CustomFields
+	}
+
+	if err := bus.Dispatch(ctx, &cmd.SetUserCustomFields{TenantID: user.Tenant.ID, UserID: a.UserID, CustomFields: a.CustomFields}); err != nil {
+		return err
+	}
+	return recordAudit(ctx, user, "user.set_custom_fields", a.UserID, before, a.CustomFields)
+}
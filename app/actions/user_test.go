@@ -6,10 +6,12 @@ import (
 
 	"github.com/getfider/fider/app"
 	"github.com/getfider/fider/app/actions"
+	"github.com/getfider/fider/app/models/cmd"
 	"github.com/getfider/fider/app/models/entity"
 	"github.com/getfider/fider/app/models/enum"
 	"github.com/getfider/fider/app/models/query"
 	. "github.com/getfider/fider/app/pkg/assert"
+	"github.com/getfider/fider/app/pkg/authz"
 	"github.com/getfider/fider/app/pkg/bus"
 	"github.com/getfider/fider/app/pkg/rand"
 )
@@ -94,6 +96,16 @@ func TestCreateUser_CollaboratorAuthorized(t *testing.T) {
 	Expect(action.IsAuthorized(context.Background(), collaborator)).IsTrue()
 }
 
+func TestCreateUser_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := &actions.CreateUser{Name: "New User"}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserInvite))
+	Expect(action.IsAuthorized(ctx, visitor)).IsTrue()
+}
+
 func TestCreateUser_AdminAuthorized(t *testing.T) {
 	RegisterT(t)
 
@@ -102,6 +114,31 @@ func TestCreateUser_AdminAuthorized(t *testing.T) {
 	Expect(action.IsAuthorized(context.Background(), admin)).IsTrue()
 }
 
+func TestCreateUser_Execute(t *testing.T) {
+	RegisterT(t)
+
+	var created *cmd.CreateUser
+	var audited *cmd.RecordAuditLog
+	bus.AddHandler(func(ctx context.Context, c *cmd.CreateUser) error {
+		c.Result = &entity.User{ID: 42, Name: c.Name}
+		created = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		audited = c
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := &actions.CreateUser{Name: "Jon Snow", Email: "jon.snow@got.com"}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(created.Name).Equals("Jon Snow")
+	Expect(audited.Action).Equals("user.create")
+	Expect(audited.TargetID).Equals(42)
+}
+
 func TestCreateUser_VisitorNotAuthorized(t *testing.T) {
 	RegisterT(t)
 
@@ -131,6 +168,16 @@ func TestChangeUserRole_Authorized(t *testing.T) {
 	Expect(action.IsAuthorized(context.Background(), user)).IsTrue()
 }
 
+func TestChangeUserRole_AuthorizedViaPermission(t *testing.T) {
+	RegisterT(t)
+
+	collaborator := &entity.User{ID: 2, Role: enum.RoleCollaborator}
+	action := actions.ChangeUserRole{UserID: 1}
+
+	ctx := authz.WithPermissions(context.Background(), enum.NewPermissionSet(enum.PermissionUserManage))
+	Expect(action.IsAuthorized(ctx, collaborator)).IsTrue()
+}
+
 func TestChangeUserRole_InvalidRole(t *testing.T) {
 	RegisterT(t)
 
@@ -210,6 +257,57 @@ func TestChangeUserRole_CurrentUser(t *testing.T) {
 	ExpectFailed(result, "userID")
 }
 
+func TestChangeUserRole_Execute_AssignsSystemRole(t *testing.T) {
+	RegisterT(t)
+
+	systemRole := &entity.CustomRole{ID: 3, TenantID: 1, IsSystem: true, BuiltIn: enum.RoleAdministrator}
+	var assigned *cmd.AssignUserRoles
+	var audited *cmd.RecordAuditLog
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRolesByTenant) error {
+		q.Result = []*entity.CustomRole{systemRole}
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetRoleByID) error {
+		if q.RoleID == systemRole.ID {
+			q.Result = systemRole
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.AssignUserRoles) error {
+		assigned = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		audited = c
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := actions.ChangeUserRole{UserID: 2, Role: enum.RoleAdministrator}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(assigned.RoleIDs).Equals([]int{systemRole.ID})
+	Expect(audited.Action).Equals("user.change_role")
+}
+
+func TestChangeUserRole_Execute_NoMatchingSystemRole(t *testing.T) {
+	RegisterT(t)
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetRolesByTenant) error {
+		q.Result = nil
+		return nil
+	})
+
+	currentUser := &entity.User{ID: 1, Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := actions.ChangeUserRole{UserID: 2, Role: enum.RoleAdministrator}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err).Equals(app.ErrNotFound)
+}
+
 func TestSetUserCustomFields_Unauthorized(t *testing.T) {
 	RegisterT(t)
 
@@ -359,3 +457,158 @@ func TestSetUserCustomFields_NilCustomFields(t *testing.T) {
 	result := action.Validate(context.Background(), currentUser)
 	ExpectSuccess(result)
 }
+
+func TestSetUserCustomFields_Execute(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:           2,
+		Tenant:       &entity.Tenant{ID: 1},
+		CustomFields: map[string]interface{}{"mrr": float64(50)},
+	}
+
+	var dispatched *cmd.SetUserCustomFields
+	var audited *cmd.RecordAuditLog
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.SetUserCustomFields) error {
+		dispatched = c
+		return nil
+	})
+	bus.AddHandler(func(ctx context.Context, c *cmd.RecordAuditLog) error {
+		audited = c
+		return nil
+	})
+
+	currentUser := &entity.User{
+		Tenant: &entity.Tenant{ID: 1},
+		Role:   enum.RoleAdministrator,
+	}
+
+	action := actions.SetUserCustomFields{
+		UserID:       targetUser.ID,
+		CustomFields: map[string]interface{}{"mrr": float64(100)},
+	}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(dispatched.UserID).Equals(targetUser.ID)
+	Expect(audited.Action).Equals("user.set_custom_fields")
+	Expect(audited.Before).Equals(targetUser.CustomFields)
+}
+
+func TestGetUserCustomFields_Unauthorized(t *testing.T) {
+	RegisterT(t)
+
+	visitor := &entity.User{ID: 1, Role: enum.RoleVisitor}
+	action := actions.GetUserCustomFields{UserID: 2}
+	Expect(action.IsAuthorized(context.Background(), visitor)).IsFalse()
+}
+
+func TestGetUserCustomFields_Execute_MasksPIIForNonAdmin(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:     2,
+		Tenant: &entity.Tenant{ID: 1},
+		CustomFields: map[string]interface{}{
+			"ssn": "123-45-6789",
+			"mrr": float64(50),
+		},
+	}
+	schema := &entity.CustomFieldSchema{
+		TenantID: 1,
+		Fields: []entity.CustomFieldDefinition{
+			{Name: "ssn", Type: enum.CustomFieldString, PII: true},
+			{Name: "mrr", Type: enum.CustomFieldNumber},
+		},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldSchema) error {
+		q.Result = schema
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleCollaborator}
+	action := actions.GetUserCustomFields{UserID: targetUser.ID}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(action.Result).Equals(map[string]interface{}{"mrr": float64(50)})
+}
+
+func TestGetUserCustomFields_Execute_AdminSeesUnmasked(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:     2,
+		Tenant: &entity.Tenant{ID: 1},
+		CustomFields: map[string]interface{}{
+			"ssn": "123-45-6789",
+		},
+	}
+	schema := &entity.CustomFieldSchema{
+		TenantID: 1,
+		Fields:   []entity.CustomFieldDefinition{{Name: "ssn", Type: enum.CustomFieldString, PII: true}},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldSchema) error {
+		q.Result = schema
+		return nil
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleAdministrator}
+	action := actions.GetUserCustomFields{UserID: targetUser.ID}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(action.Result).Equals(targetUser.CustomFields)
+}
+
+func TestGetUserCustomFields_Execute_WithholdsFieldsWhenSchemaUnavailable(t *testing.T) {
+	RegisterT(t)
+
+	targetUser := &entity.User{
+		ID:           2,
+		Tenant:       &entity.Tenant{ID: 1},
+		CustomFields: map[string]interface{}{"ssn": "123-45-6789"},
+	}
+
+	bus.AddHandler(func(ctx context.Context, q *query.GetUserByID) error {
+		if q.UserID == targetUser.ID {
+			q.Result = targetUser
+			return nil
+		}
+		return app.ErrNotFound
+	})
+	bus.AddHandler(func(ctx context.Context, q *query.GetCustomFieldSchema) error {
+		return app.ErrNotFound
+	})
+
+	currentUser := &entity.User{Tenant: &entity.Tenant{ID: 1}, Role: enum.RoleCollaborator}
+	action := actions.GetUserCustomFields{UserID: targetUser.ID}
+	err := action.Execute(context.Background(), currentUser)
+
+	Expect(err == nil).IsTrue()
+	Expect(action.Result == nil).IsTrue()
+}
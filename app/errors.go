@@ -0,0 +1,13 @@
+package app
+
+import "errors"
+
+// Common errors returned by queries and actions across the application.
+var (
+	// ErrNotFound is returned when a requested resource could not be located,
+	// either because it doesn't exist or because it's outside the caller's tenant.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized is returned when the current user is not allowed to perform an action.
+	ErrUnauthorized = errors.New("unauthorized")
+)
@@ -0,0 +1,36 @@
+package cmd
+
+import "time"
+
+// CreateAPIKey creates a new API key for a service account user and returns
+// the plaintext secret via Result (it is never stored or retrievable again).
+type CreateAPIKey struct {
+	TenantID      int
+	UserID        int
+	Name          string
+	BoundCIDRs    []string
+	UsesRemaining *int
+	ExpiresAt     *time.Time
+
+	Result struct {
+		APIKeyID int
+		RoleID   string
+		Secret   string
+	}
+}
+
+// RotateAPIKeySecret generates and persists a new secret for an existing
+// API key, invalidating the previous one. The plaintext secret is returned
+// via Result.
+type RotateAPIKeySecret struct {
+	TenantID int
+	APIKeyID int
+
+	Result string
+}
+
+// RevokeAPIKey deletes an API key, making its credentials unusable.
+type RevokeAPIKey struct {
+	TenantID int
+	APIKeyID int
+}
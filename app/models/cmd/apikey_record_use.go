@@ -0,0 +1,9 @@
+package cmd
+
+// RecordAPIKeyUse updates an API key's LastUsedAt timestamp and decrements
+// its remaining uses counter (when bounded). Dispatched once per
+// successful Bearer authentication.
+type RecordAPIKeyUse struct {
+	TenantID int
+	APIKeyID int
+}
@@ -0,0 +1,15 @@
+package cmd
+
+// RecordAuditLog persists a single audit trail entry. Before/After are
+// marshaled to JSON by the handler; they're typically the entity (or
+// relevant slice of fields) as it looked immediately before and after the
+// action was applied.
+type RecordAuditLog struct {
+	TenantID int
+	ActorID  int
+	TargetID int
+	Action   string
+	Before   interface{}
+	After    interface{}
+	IP       string
+}
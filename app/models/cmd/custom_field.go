@@ -0,0 +1,10 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/entity"
+
+// DefineCustomField registers (or replaces) a single custom field
+// definition on the tenant's schema.
+type DefineCustomField struct {
+	TenantID   int
+	Definition entity.CustomFieldDefinition
+}
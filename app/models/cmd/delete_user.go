@@ -0,0 +1,49 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/enum"
+
+// RemoveUserRoleAssignments removes every CustomRole assigned to a user.
+type RemoveUserRoleAssignments struct {
+	TenantID int
+	UserID   int
+}
+
+// RevokeAllAPIKeysForUser revokes every API key belonging to a user.
+type RevokeAllAPIKeysForUser struct {
+	TenantID int
+	UserID   int
+}
+
+// WipeUserCustomFields clears every custom field value set on a user.
+type WipeUserCustomFields struct {
+	TenantID int
+	UserID   int
+}
+
+// AnonymizeUserContent handles the posts/comments authored by a deleted
+// user, either deleting them or reassigning them to the tenant's
+// "Deleted User" sentinel account, per Mode.
+type AnonymizeUserContent struct {
+	TenantID int
+	UserID   int
+	Mode     enum.AnonymizeMode
+}
+
+// InvalidateUserSessions terminates every active session for a user.
+type InvalidateUserSessions struct {
+	TenantID int
+	UserID   int
+}
+
+// UnlinkOAuthProviders removes every OAuth provider link for a user.
+type UnlinkOAuthProviders struct {
+	TenantID int
+	UserID   int
+}
+
+// DeleteUserRecord deletes the user row itself. It must run after every
+// other cascade step so foreign keys referencing it are already cleared.
+type DeleteUserRecord struct {
+	TenantID int
+	UserID   int
+}
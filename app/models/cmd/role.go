@@ -0,0 +1,24 @@
+package cmd
+
+import "github.com/getfider/fider/app/models/enum"
+
+// CreateOrUpdateRole persists a new custom role, or updates an existing one
+// when RoleID is non-zero.
+type CreateOrUpdateRole struct {
+	TenantID    int
+	RoleID      int
+	Name        string
+	Permissions []enum.Permission
+}
+
+// AssignUserRoles persists the set of custom roles assigned to a user,
+// replacing whatever was previously assigned. When RoleIDs resolves to one
+// of the seeded system roles, BuiltInRole carries the enum.Role it backs;
+// the handler must also write that value onto entity.User.Role so the
+// legacy Role-gated checks keep reflecting the user's current role.
+type AssignUserRoles struct {
+	TenantID    int
+	UserID      int
+	RoleIDs     []int
+	BuiltInRole enum.Role
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// CreateUser persists a new user and returns it via Result. Kind defaults to
+// enum.UserKindHuman when left zero.
+type CreateUser struct {
+	TenantID  int
+	Name      string
+	Email     string
+	Reference string
+	Kind      enum.UserKind
+
+	Result *entity.User
+}
+
+// SetUserCustomFields overwrites a user's custom field values.
+type SetUserCustomFields struct {
+	TenantID     int
+	UserID       int
+	CustomFields map[string]interface{}
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// APIKey is an AppRole-style credential (role_id + secret_id) bound to a
+// service account user, used for programmatic access instead of cookie auth.
+type APIKey struct {
+	ID           int      `json:"id"`
+	TenantID     int      `json:"-"`
+	UserID       int      `json:"userID"`
+	Name         string   `json:"name"`
+	RoleID       string   `json:"roleID"`
+	HashedSecret string   `json:"-"`
+	BoundCIDRs   []string `json:"boundCIDRs,omitempty"`
+
+	// UsesRemaining is decremented on every successful authentication.
+	// A nil value means the key has unlimited uses.
+	UsesRemaining *int `json:"usesRemaining,omitempty"`
+
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
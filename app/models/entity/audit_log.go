@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// AuditLog is an immutable record of a sensitive action taken against a
+// user: a role change, a custom field edit, account creation/deletion, or
+// an API key mint/revoke.
+type AuditLog struct {
+	ID        int       `json:"id"`
+	TenantID  int       `json:"-"`
+	ActorID   int       `json:"actorID"`
+	TargetID  int       `json:"targetID"`
+	Action    string    `json:"action"`
+	Before    string    `json:"before,omitempty"`
+	After     string    `json:"after,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "github.com/getfider/fider/app/models/enum"
+
+// CustomFieldDefinition declares a single tenant-defined field that may be
+// set through actions.SetUserCustomFields.
+type CustomFieldDefinition struct {
+	Name string               `json:"name"`
+	Type enum.CustomFieldType `json:"type"`
+
+	// Constraints, only the ones relevant to Type are honored.
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	Regex      string   `json:"regex,omitempty"`
+	EnumValues []string `json:"enumValues,omitempty"`
+
+	Required bool `json:"required"`
+	Indexed  bool `json:"indexed"`
+
+	// PII marks the field as personally identifiable information; it's
+	// stripped from API responses served to non-admin users.
+	PII bool `json:"pii"`
+}
+
+// CustomFieldSchema is the full set of custom fields registered for a tenant.
+type CustomFieldSchema struct {
+	TenantID int                     `json:"-"`
+	Fields   []CustomFieldDefinition `json:"fields"`
+
+	// AdditionalFields, when true, allows keys outside Fields to still be
+	// set as untyped primitives (legacy behavior, kept for tenants that
+	// haven't fully defined their schema yet).
+	AdditionalFields bool `json:"additionalFields"`
+}
+
+// FieldByName returns the definition for name, or nil if it isn't registered.
+func (s *CustomFieldSchema) FieldByName(name string) *CustomFieldDefinition {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i]
+		}
+	}
+	return nil
+}
+
+// MaskPII returns a copy of fields with every value whose definition is
+// flagged PII removed, so it's safe to serve to non-admin API callers.
+func (s *CustomFieldSchema) MaskPII(fields map[string]interface{}) map[string]interface{} {
+	masked := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		if def := s.FieldByName(name); def != nil && def.PII {
+			continue
+		}
+		masked[name] = value
+	}
+	return masked
+}
@@ -0,0 +1,30 @@
+package entity
+
+import "github.com/getfider/fider/app/models/enum"
+
+// CustomRole is a tenant-defined named collection of permissions. Every
+// tenant is seeded with three immutable system roles (Visitor, Collaborator,
+// Administrator) mirroring the legacy enum.Role so upgrades don't break
+// existing role assignments; administrators may additionally define their
+// own roles on top of those.
+type CustomRole struct {
+	ID          int               `json:"id"`
+	TenantID    int               `json:"-"`
+	Name        string            `json:"name"`
+	Permissions []enum.Permission `json:"permissions"`
+
+	// IsSystem marks one of the three seeded built-in roles. System roles
+	// always exist and cannot be renamed, re-permissioned or deleted.
+	IsSystem bool `json:"isSystem"`
+
+	// BuiltIn is set on system roles only, pointing back at the legacy role
+	// it replaces so actions.ChangeUserRole can keep working as a shim.
+	BuiltIn enum.Role `json:"-"`
+}
+
+// UserRole assigns a CustomRole to a user. A user may hold more than one
+// role; the permissions granted are the union of every assigned role.
+type UserRole struct {
+	UserID int `json:"userID"`
+	RoleID int `json:"roleID"`
+}
@@ -0,0 +1,7 @@
+package entity
+
+// Tenant represents a single Fider instance/site.
+type Tenant struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
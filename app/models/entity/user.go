@@ -0,0 +1,15 @@
+package entity
+
+import "github.com/getfider/fider/app/models/enum"
+
+// User is a person (or service account) registered on a given tenant.
+type User struct {
+	ID           int                    `json:"id"`
+	Name         string                 `json:"name"`
+	Email        string                 `json:"email"`
+	Reference    string                 `json:"reference"`
+	Role         enum.Role              `json:"role"`
+	Kind         enum.UserKind          `json:"kind"`
+	Tenant       *Tenant                `json:"-"`
+	CustomFields map[string]interface{} `json:"customFields,omitempty"`
+}
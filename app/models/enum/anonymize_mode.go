@@ -0,0 +1,21 @@
+package enum
+
+// AnonymizeMode controls what happens to content authored by a deleted user.
+type AnonymizeMode string
+
+const (
+	// AnonymizeModeDelete removes the user's posts/comments entirely.
+	AnonymizeModeDelete AnonymizeMode = "delete"
+	// AnonymizeModeReassign reassigns the user's posts/comments to a
+	// tenant-wide "Deleted User" sentinel account instead of deleting them.
+	AnonymizeModeReassign AnonymizeMode = "reassign"
+)
+
+// IsValid returns true if m is a known anonymize mode.
+func (m AnonymizeMode) IsValid() bool {
+	switch m {
+	case AnonymizeModeDelete, AnonymizeModeReassign:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package enum
+
+// CustomFieldType is the declared type of a tenant-defined custom field.
+type CustomFieldType string
+
+const (
+	CustomFieldString CustomFieldType = "string"
+	CustomFieldNumber CustomFieldType = "number"
+	CustomFieldBool   CustomFieldType = "bool"
+	CustomFieldEnum   CustomFieldType = "enum"
+	CustomFieldDate   CustomFieldType = "date"
+	CustomFieldURL    CustomFieldType = "url"
+)
+
+// IsValid returns true if t is a known custom field type.
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldString, CustomFieldNumber, CustomFieldBool, CustomFieldEnum, CustomFieldDate, CustomFieldURL:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package enum
+
+// Permission is an atomic capability that can be granted to a CustomRole.
+// Unlike the fixed Role enum, the permission catalog is open-ended and new
+// entries can be appended without breaking existing tenants (existing roles
+// simply don't carry the new bit).
+type Permission string
+
+const (
+	PermissionPostCreate        Permission = "post.create"
+	PermissionPostEditAny       Permission = "post.edit_any"
+	PermissionCommentModerate   Permission = "comment.moderate"
+	PermissionUserInvite        Permission = "user.invite"
+	PermissionSettingsEdit      Permission = "settings.edit"
+	PermissionBillingManage     Permission = "billing.manage"
+	PermissionRoleManage        Permission = "role.manage"
+	PermissionUserManage        Permission = "user.manage"
+	PermissionAPIKeyManage      Permission = "apikey.manage"
+	PermissionCustomFieldManage Permission = "customfield.manage"
+)
+
+// AllPermissions is the registered catalog of every permission that may be
+// attached to a custom role. Role definitions are validated against it.
+var AllPermissions = []Permission{
+	PermissionPostCreate,
+	PermissionPostEditAny,
+	PermissionCommentModerate,
+	PermissionUserInvite,
+	PermissionSettingsEdit,
+	PermissionBillingManage,
+	PermissionRoleManage,
+	PermissionUserManage,
+	PermissionAPIKeyManage,
+	PermissionCustomFieldManage,
+}
+
+// IsValid returns true if p is a registered permission.
+func (p Permission) IsValid() bool {
+	for _, known := range AllPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// bit returns the single-bit mask p occupies in a PermissionSet bitset, or 0
+// if p isn't registered.
+func (p Permission) bit() uint64 {
+	for i, known := range AllPermissions {
+		if known == p {
+			return 1 << uint(i)
+		}
+	}
+	return 0
+}
+
+// PermissionSet is the union of every permission granted to a user through
+// all of their assigned roles, packed as a bitset so it's cheap to carry on
+// context.Context and check on every request.
+type PermissionSet uint64
+
+// NewPermissionSet builds a PermissionSet out of a list of permissions.
+func NewPermissionSet(perms ...Permission) PermissionSet {
+	var set PermissionSet
+	for _, p := range perms {
+		set |= PermissionSet(p.bit())
+	}
+	return set
+}
+
+// Has returns true if p is included in the set.
+func (s PermissionSet) Has(p Permission) bool {
+	return s&PermissionSet(p.bit()) != 0
+}
+
+// Union merges other into s, returning the combined set.
+func (s PermissionSet) Union(other PermissionSet) PermissionSet {
+	return s | other
+}
@@ -0,0 +1,31 @@
+package enum
+
+// Role represents the fixed set of built-in roles a user can hold within a tenant.
+type Role int
+
+const (
+	RoleVisitor Role = iota + 1
+	RoleCollaborator
+	RoleAdministrator
+)
+
+// IsValid returns true if r is one of the known built-in roles.
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleVisitor, RoleCollaborator, RoleAdministrator:
+		return true
+	}
+	return false
+}
+
+func (r Role) String() string {
+	switch r {
+	case RoleVisitor:
+		return "visitor"
+	case RoleCollaborator:
+		return "collaborator"
+	case RoleAdministrator:
+		return "administrator"
+	}
+	return "unknown"
+}
@@ -0,0 +1,10 @@
+package enum
+
+// UserKind distinguishes a human user from a service account used for
+// programmatic (API key based) access.
+type UserKind int
+
+const (
+	UserKindHuman UserKind = iota + 1
+	UserKindServiceAccount
+)
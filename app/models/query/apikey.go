@@ -0,0 +1,25 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetAPIKeyByID fetches a single API key, scoped to the current tenant.
+type GetAPIKeyByID struct {
+	APIKeyID int
+
+	Result *entity.APIKey
+}
+
+// GetAPIKeyByRoleID resolves an API key from the role_id half of its
+// credentials, used during Bearer token authentication.
+type GetAPIKeyByRoleID struct {
+	RoleID string
+
+	Result *entity.APIKey
+}
+
+// ListAPIKeysByTenant lists every API key belonging to a tenant.
+type ListAPIKeysByTenant struct {
+	TenantID int
+
+	Result []*entity.APIKey
+}
@@ -0,0 +1,14 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// ListAuditLogs lists audit log entries for a tenant, optionally filtered
+// by actor and/or target user. Zero means "no filter" for both.
+type ListAuditLogs struct {
+	TenantID int
+	ActorID  int
+	TargetID int
+	Action   string
+
+	Result []*entity.AuditLog
+}
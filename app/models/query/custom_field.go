@@ -0,0 +1,21 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetCustomFieldSchema fetches the tenant's registered custom field schema.
+type GetCustomFieldSchema struct {
+	TenantID int
+
+	Result *entity.CustomFieldSchema
+}
+
+// SearchUsersByCustomField looks up users whose custom field FieldName
+// equals Value. FieldName must be registered with Indexed: true in the
+// tenant's schema.
+type SearchUsersByCustomField struct {
+	TenantID  int
+	FieldName string
+	Value     interface{}
+
+	Result []*entity.User
+}
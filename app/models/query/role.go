@@ -0,0 +1,37 @@
+package query
+
+import (
+	"github.com/getfider/fider/app/models/entity"
+	"github.com/getfider/fider/app/models/enum"
+)
+
+// GetRoleByID fetches a single custom role, scoped to the current tenant.
+type GetRoleByID struct {
+	RoleID int
+
+	Result *entity.CustomRole
+}
+
+// GetRolesByTenant lists every custom role (system and user-defined) for a tenant.
+type GetRolesByTenant struct {
+	TenantID int
+
+	Result []*entity.CustomRole
+}
+
+// GetUsersByRole lists every user that has a given role assigned.
+type GetUsersByRole struct {
+	TenantID int
+	RoleID   int
+
+	Result []*entity.User
+}
+
+// GetPermissionsForUser resolves the union of permissions granted to a user
+// through every role assigned to them.
+type GetPermissionsForUser struct {
+	TenantID int
+	UserID   int
+
+	Result []enum.Permission
+}
@@ -0,0 +1,10 @@
+package query
+
+import "github.com/getfider/fider/app/models/entity"
+
+// GetUserByID fetches a user by its internal ID, scoped to the current tenant.
+type GetUserByID struct {
+	UserID int
+
+	Result *entity.User
+}
@@ -0,0 +1,77 @@
+// Package assert provides the small expectation DSL used throughout the
+// test suite (RegisterT, Expect, ExpectSuccess, ExpectFailed).
+package assert
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/getfider/fider/app/pkg/bus"
+	"github.com/getfider/fider/app/pkg/validate"
+)
+
+var t *testing.T
+
+// RegisterT must be called at the start of every test. It resets the bus
+// handler registry so that fake handlers registered by one test don't leak
+// into the next.
+func RegisterT(currentT *testing.T) {
+	t = currentT
+	bus.Reset()
+}
+
+// Expectation wraps a value so assertions can be chained off Expect(...).
+type Expectation struct {
+	actual interface{}
+}
+
+// Expect starts an assertion on actual.
+func Expect(actual interface{}) *Expectation {
+	return &Expectation{actual: actual}
+}
+
+// IsTrue asserts the wrapped value is the boolean true.
+func (e *Expectation) IsTrue() {
+	t.Helper()
+	if b, ok := e.actual.(bool); !ok || !b {
+		t.Errorf("expected true, got %v", e.actual)
+	}
+}
+
+// IsFalse asserts the wrapped value is the boolean false.
+func (e *Expectation) IsFalse() {
+	t.Helper()
+	if b, ok := e.actual.(bool); !ok || b {
+		t.Errorf("expected false, got %v", e.actual)
+	}
+}
+
+// Equals asserts the wrapped value deep-equals expected.
+func (e *Expectation) Equals(expected interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(e.actual, expected) {
+		t.Errorf("expected %v, got %v", expected, e.actual)
+	}
+}
+
+// ExpectSuccess asserts result has no top-level error and no field failures.
+func ExpectSuccess(result *validate.Result) {
+	t.Helper()
+	if !result.Ok() {
+		t.Errorf("expected success, got failures: %v (err: %v)", result.Failures, result.Err)
+	}
+}
+
+// ExpectFailed asserts result has a failure registered against every field in fields.
+func ExpectFailed(result *validate.Result, fields ...string) {
+	t.Helper()
+	if result.Ok() {
+		t.Errorf("expected failure on fields %v, got success", fields)
+		return
+	}
+	for _, field := range fields {
+		if _, ok := result.Failures[field]; !ok {
+			t.Errorf("expected failure on field %q, got %v", field, result.Failures)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package authz exposes the resolved RBAC v2 permission set for the current
+// request. web.PermissionsMiddleware resolves the union of permissions
+// granted by every CustomRole assigned to the authenticated user and
+// attaches it to the request's context.Context via WithPermissions; call
+// sites gate on one of the granular enum.Permission entries with
+// HasPermission.
+//
+// Every actions.* IsAuthorized check in the RBAC v2 series now accepts
+// HasPermission as an alternative to its entity.User.Role gate, each mapped
+// to the permission that matches what the action actually does (for example
+// actions.DefineCustomField accepts PermissionCustomFieldManage, and
+// actions.CreateServiceAccount/RotateAPIKey/RevokeAPIKey/ListAPIKeys accept
+// PermissionAPIKeyManage). This lets a tenant grant a CustomRole exactly the
+// one capability a user needs without promoting them to a full built-in
+// role. The Role gate itself is never removed: actions.AssignUserRoles keeps
+// entity.User.Role in sync whenever a seeded system role is (re)assigned, so
+// existing integrations that only understand the three built-in roles keep
+// working unchanged.
+package authz
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/enum"
+)
+
+type contextKey int
+
+const permissionSetKey contextKey = iota
+
+// WithPermissions returns a copy of ctx carrying the given PermissionSet.
+func WithPermissions(ctx context.Context, set enum.PermissionSet) context.Context {
+	return context.WithValue(ctx, permissionSetKey, set)
+}
+
+// HasPermission returns true if the PermissionSet attached to ctx grants perm.
+// A context with no PermissionSet attached (e.g. unauthenticated requests)
+// never has any permission.
+func HasPermission(ctx context.Context, perm enum.Permission) bool {
+	set, ok := ctx.Value(permissionSetKey).(enum.PermissionSet)
+	if !ok {
+		return false
+	}
+	return set.Has(perm)
+}
@@ -0,0 +1,49 @@
+// Package bus provides a minimal in-process message bus used to decouple
+// actions and queries from their concrete handlers. Handlers are registered
+// per message type and invoked in registration order by Dispatch.
+package bus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+type handlerFunc struct {
+	fn reflect.Value
+}
+
+var handlers = map[reflect.Type][]handlerFunc{}
+
+// AddHandler registers fn to be invoked whenever a message of type T is dispatched.
+func AddHandler[T any](fn func(ctx context.Context, msg T) error) {
+	var msg T
+	t := reflect.TypeOf(msg)
+	handlers[t] = append(handlers[t], handlerFunc{fn: reflect.ValueOf(fn)})
+}
+
+// Dispatch invokes every handler registered for msg's type, stopping at the
+// first error returned. msg is typically a pointer whose Result field is
+// populated by the handler. Dispatching a message type with no registered
+// handler is an error: every query/command is expected to have exactly one
+// handler wired up at runtime.
+func Dispatch(ctx context.Context, msg interface{}) error {
+	t := reflect.TypeOf(msg)
+	hs, ok := handlers[t]
+	if !ok {
+		return fmt.Errorf("bus: no handler registered for %s", t)
+	}
+	for _, h := range hs {
+		out := h.fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(msg)})
+		if err, _ := out[0].Interface().(error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset removes every registered handler. Used by tests to isolate cases
+// that register their own fake handlers.
+func Reset() {
+	handlers = map[reflect.Type][]handlerFunc{}
+}
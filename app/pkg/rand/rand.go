@@ -0,0 +1,26 @@
+// Package rand provides small randomness helpers used by tests and by
+// code that needs to generate opaque tokens (API keys, secrets, etc).
+package rand
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// String returns a cryptographically random alphabetic string of the given
+// length, suitable for API key secrets and other tokens that must not be
+// guessable. Panics if the system's CSPRNG fails to produce randomness,
+// which only happens if the OS entropy source itself is broken.
+func String(length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
+		if err != nil {
+			panic(err)
+		}
+		b[i] = letters[n.Int64()]
+	}
+	return string(b)
+}
@@ -0,0 +1,31 @@
+package validate
+
+// Result is returned by every action's Validate method. It carries either
+// a hard, top-level error (Err) or a set of per-field Failures, never both.
+type Result struct {
+	Err      error
+	Failures map[string][]string
+}
+
+// Success returns a Result with no failures.
+func Success() *Result {
+	return &Result{Failures: make(map[string][]string)}
+}
+
+// Error wraps a top-level error that aborts validation entirely.
+func Error(err error) *Result {
+	return &Result{Err: err, Failures: make(map[string][]string)}
+}
+
+// AddFieldFailure records a validation failure for a given field name.
+func (r *Result) AddFieldFailure(field, message string) {
+	if r.Failures == nil {
+		r.Failures = make(map[string][]string)
+	}
+	r.Failures[field] = append(r.Failures[field], message)
+}
+
+// Ok returns true if the result has no top-level error and no field failures.
+func (r *Result) Ok() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
@@ -0,0 +1,42 @@
+// Package web holds small HTTP-layer helpers shared by every authentication
+// middleware (cookie-based and, for service accounts, Bearer API keys).
+package web
+
+import (
+	"context"
+
+	"github.com/getfider/fider/app/models/entity"
+)
+
+type contextKey int
+
+const (
+	userContextKey contextKey = iota
+	remoteIPContextKey
+)
+
+// WithUser returns a copy of ctx carrying user. Both the cookie session
+// middleware and the API key middleware call this so every downstream
+// IsAuthorized/Validate check works identically regardless of how the
+// caller authenticated.
+func WithUser(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+// UserFromContext returns the user attached to ctx, or nil if there is none.
+func UserFromContext(ctx context.Context) *entity.User {
+	user, _ := ctx.Value(userContextKey).(*entity.User)
+	return user
+}
+
+// WithRemoteIP returns a copy of ctx carrying the caller's remote IP, set by
+// the top-level request middleware so actions can attach it to audit log entries.
+func WithRemoteIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, remoteIPContextKey, ip)
+}
+
+// RemoteIPFromContext returns the remote IP attached to ctx, or "" if there is none.
+func RemoteIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(remoteIPContextKey).(string)
+	return ip
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/getfider/fider/app/models/enum"
+	"github.com/getfider/fider/app/models/query"
+	"github.com/getfider/fider/app/pkg/authz"
+	"github.com/getfider/fider/app/pkg/bus"
+)
+
+// PermissionsMiddleware resolves the authenticated user's RBAC v2 permissions
+// (the union granted by every CustomRole assigned to them) and attaches the
+// resulting enum.PermissionSet to the request context via
+// authz.WithPermissions, so downstream IsAuthorized checks can call
+// authz.HasPermission for the granular capabilities a custom role can carry,
+// alongside the coarser entity.User.Role checks most actions still rely on.
+// Must run after WithUser has attached the authenticated user; requests
+// without one (and requests whose permissions fail to resolve) pass through
+// with no permissions attached, so HasPermission simply returns false.
+func PermissionsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		user := UserFromContext(ctx)
+		if user == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		getPermissions := &query.GetPermissionsForUser{TenantID: user.Tenant.ID, UserID: user.ID}
+		if err := bus.Dispatch(ctx, getPermissions); err == nil {
+			r = r.WithContext(authz.WithPermissions(ctx, enum.NewPermissionSet(getPermissions.Result...)))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}